@@ -30,6 +30,24 @@ const (
 	// KindSlin indicates the message contains signed-linear audio data
 	KindSlin = 0x10
 
+	// KindULaw indicates the message contains G.711 mu-law audio data
+	KindULaw = 0x11
+
+	// KindALaw indicates the message contains G.711 A-law audio data
+	KindALaw = 0x12
+
+	// KindSlin16 indicates the message contains 16kHz signed-linear audio data
+	KindSlin16 = 0x13
+
+	// KindSlin24 indicates the message contains 24kHz signed-linear audio data
+	KindSlin24 = 0x14
+
+	// KindSlin48 indicates the message contains 48kHz signed-linear audio data
+	KindSlin48 = 0x15
+
+	// KindOpus indicates the message contains Opus-encoded audio data
+	KindOpus = 0x16
+
 	// KindError indicates the message contains an error code
 	KindError = 0xff
 )
@@ -161,12 +179,19 @@ func IDMessage(id uuid.UUID) Message {
 // SlinMessage creates a new Message from signed linear audio data
 // If the input is larger than 65535 bytes, this function will panic.
 func SlinMessage(in []byte) Message {
+	return AudioMessage(KindSlin, in)
+}
+
+// AudioMessage creates a new Message of the given Kind from already-encoded
+// audio data, such as the output of a codec.Encoder.  If the input is larger
+// than 65535 bytes, this function will panic.
+func AudioMessage(kind Kind, in []byte) Message {
 	if len(in) > 65535 {
 		panic("audiosocket: message too large")
 	}
 
 	out := make([]byte, 3, 3+len(in))
-	out[0] = KindSlin
+	out[0] = byte(kind)
 	binary.BigEndian.PutUint16(out[1:], uint16(len(in)))
 	out = append(out, in...)
 	return out
@@ -3,7 +3,8 @@ package audiosocket
 import (
 	"fmt"
 	"io"
-	"time"
+
+	"github.com/CyCoreSystems/audiosocket/codec"
 )
 
 // DefaultSlinChunkSize is the number of bytes which should be sent per slin
@@ -13,27 +14,64 @@ const DefaultSlinChunkSize = 320 // 8000Hz * 20ms * 2 bytes
 
 // SendSlinChunks takes signed linear data and sends it over an AudioSocket connection in chunks of the given size.
 func SendSlinChunks(w io.Writer, chunkSize int, input []byte) error {
-	var chunks int
-
 	if chunkSize < 1 {
 		chunkSize = DefaultSlinChunkSize
 	}
 
-	t := time.NewTicker(20 * time.Millisecond)
-	defer t.Stop()
+	p := NewPacer(w, codec.FrameDuration)
 
 	for i := 0; i < len(input); {
-		<-t.C
 		chunkLen := chunkSize
 		if i+chunkSize > len(input) {
 			chunkLen = len(input) - i
 		}
-		if _, err := w.Write(SlinMessage(input[i : i+chunkLen])); err != nil {
-			return fmt.Errorf("failted to write chunk to AudioSocket: %w", err)
+		if err := p.Send(SlinMessage(input[i : i+chunkLen])); err != nil {
+			return fmt.Errorf("failed to write chunk to AudioSocket: %w", err)
 		}
-		chunks++
 		i += chunkLen
 	}
 
 	return nil
 }
+
+// SendAudio encodes signed-linear PCM data using c and sends it over an
+// AudioSocket connection, chunking the PCM into codec.FrameDuration-length
+// frames.
+func SendAudio(w io.Writer, c codec.Codec, pcm []byte) error {
+	frameSize := pcmFrameSize(c)
+
+	p := NewPacer(w, codec.FrameDuration)
+
+	for i := 0; i < len(pcm); {
+		chunkLen := frameSize
+		if i+frameSize > len(pcm) {
+			chunkLen = len(pcm) - i
+		}
+
+		encoded, err := c.Encode(pcm[i : i+chunkLen])
+		if err != nil {
+			return fmt.Errorf("failed to encode audio chunk: %w", err)
+		}
+
+		if err := p.Send(AudioMessage(Kind(c.Kind()), encoded)); err != nil {
+			return fmt.Errorf("failed to write chunk to AudioSocket: %w", err)
+		}
+
+		i += chunkLen
+	}
+
+	return nil
+}
+
+// pcmFrameSize returns the number of signed-linear PCM bytes in one
+// codec.FrameDuration-length frame for c.  For codecs whose FrameSize counts
+// compressed wire bytes (such as G.711), this is the 16-bit mono PCM size at
+// c's sample rate; where FrameSize is already larger (multi-channel Opus),
+// FrameSize is used.
+func pcmFrameSize(c codec.Codec) int {
+	n := int(codec.FrameDuration.Seconds()*float64(c.SampleRate())) * 2
+	if fs := c.FrameSize(); fs > n {
+		return fs
+	}
+	return n
+}
@@ -0,0 +1,43 @@
+package audiosocket
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/CyCoreSystems/audiosocket/codec"
+)
+
+// TestSendAudioTerminates guards against a regression where a codec's
+// FrameSize() returning 0 made the chunking loop in SendAudio spin forever
+// instead of advancing.
+func TestSendAudioTerminates(t *testing.T) {
+	c := codec.NewSlin8()
+	pcm := make([]byte, c.FrameSize()*10+7) // not an even multiple of FrameSize
+
+	done := make(chan error, 1)
+	var buf bytes.Buffer
+	go func() {
+		done <- SendAudio(&buf, c, pcm)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("SendAudio returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SendAudio did not return within timeout; FrameSize() is likely 0 again")
+	}
+
+	wantFrames := 11 // 10 full frames plus one short final frame
+	gotFrames := 0
+	for b := buf.Bytes(); len(b) > 0; {
+		payloadLen := int(b[1])<<8 | int(b[2])
+		b = b[3+payloadLen:]
+		gotFrames++
+	}
+	if gotFrames != wantFrames {
+		t.Fatalf("got %d frames, want %d", gotFrames, wantFrames)
+	}
+}
@@ -0,0 +1,110 @@
+package codec
+
+import "fmt"
+
+const (
+	aLawKind       = 0x12
+	aLawSampleRate = 8000
+)
+
+// ALaw is the ITU-T G.711 A-law codec, as used by Asterisk's AudioSocket
+// channel driver when the dialplan requests A-law audio.
+type ALaw struct{}
+
+// NewALaw returns a Codec implementing G.711 A-law.
+func NewALaw() *ALaw {
+	return &ALaw{}
+}
+
+// Kind returns the AudioSocket message kind byte for A-law frames.
+func (c *ALaw) Kind() byte {
+	return aLawKind
+}
+
+// SampleRate returns the A-law sample rate, which is always 8kHz.
+func (c *ALaw) SampleRate() int {
+	return aLawSampleRate
+}
+
+// FrameSize returns the number of wire bytes in one 20ms A-law frame.
+func (c *ALaw) FrameSize() int {
+	return int(FrameDuration.Seconds() * float64(aLawSampleRate)) // 1 byte/sample
+}
+
+// Encode converts 16-bit signed-linear PCM into A-law-encoded bytes.
+func (c *ALaw) Encode(pcm []byte) ([]byte, error) {
+	if len(pcm)%2 != 0 {
+		return nil, fmt.Errorf("pcm length %d is not a multiple of 2", len(pcm))
+	}
+
+	out := make([]byte, len(pcm)/2)
+	for i := range out {
+		sample := int16(uint16(pcm[2*i]) | uint16(pcm[2*i+1])<<8)
+		out[i] = linearToALaw(sample)
+	}
+	return out, nil
+}
+
+// Decode converts A-law-encoded bytes into 16-bit signed-linear PCM.
+func (c *ALaw) Decode(frame []byte) ([]byte, error) {
+	out := make([]byte, len(frame)*2)
+	for i, b := range frame {
+		sample := uint16(aLawToLinear(b))
+		out[2*i] = byte(sample)
+		out[2*i+1] = byte(sample >> 8)
+	}
+	return out, nil
+}
+
+// linearToALaw encodes a single 16-bit linear sample to A-law.
+func linearToALaw(sample int16) byte {
+	const clip = 32635
+
+	s := int(sample)
+
+	sign := byte(0x80)
+	if s < 0 {
+		s = -s - 1
+		sign = 0x00
+	}
+	if s > clip {
+		s = clip
+	}
+
+	var exponent byte
+	var mantissa byte
+
+	if s >= 256 {
+		exponent = 7
+		for expMask := 0x4000; s&expMask == 0 && exponent > 0; expMask >>= 1 {
+			exponent--
+		}
+		mantissa = byte(s>>(exponent+3)) & 0x0f
+	} else {
+		exponent = 0
+		mantissa = byte(s >> 4)
+	}
+
+	return (sign | exponent<<4 | mantissa) ^ 0x55
+}
+
+// aLawToLinear decodes a single A-law byte to a 16-bit linear sample.
+func aLawToLinear(b byte) int16 {
+	b ^= 0x55
+
+	sign := b & 0x80
+	exponent := (b >> 4) & 0x07
+	mantissa := b & 0x0f
+
+	var sample int
+	if exponent == 0 {
+		sample = int(mantissa)<<4 + 8
+	} else {
+		sample = (int(mantissa)<<4 + 0x108) << (exponent - 1)
+	}
+
+	if sign == 0 {
+		sample = -sample
+	}
+	return int16(sample)
+}
@@ -0,0 +1,48 @@
+// Package codec provides encoders and decoders for the audio formats
+// Asterisk's AudioSocket channel driver can carry on the wire, along with
+// conversion to and from signed-linear PCM, which is the format used
+// everywhere else in this library.
+package codec
+
+import "time"
+
+// FrameDuration is the frame interval AudioSocket is built around.  All
+// codecs in this package chunk audio into FrameDuration-length frames.
+const FrameDuration = 20 * time.Millisecond
+
+// Encoder converts signed-linear PCM samples (16-bit, little-endian) into
+// a codec's wire format.
+type Encoder interface {
+	// Encode encodes one FrameDuration-length chunk of signed-linear PCM
+	// into the codec's wire format.
+	Encode(pcm []byte) ([]byte, error)
+}
+
+// Decoder converts a codec's wire format back into signed-linear PCM
+// samples (16-bit, little-endian).
+type Decoder interface {
+	// Decode decodes a single wire-format frame into signed-linear PCM.
+	Decode(frame []byte) ([]byte, error)
+}
+
+// Codec is an encoder/decoder pair for one of the audio formats Asterisk's
+// AudioSocket channel driver can carry.
+type Codec interface {
+	Encoder
+	Decoder
+
+	// Kind returns the raw AudioSocket message kind byte used to tag
+	// frames of this codec on the wire.  It is typed as a byte, rather
+	// than audiosocket.Kind, so that this package does not need to
+	// import the audiosocket package.
+	Kind() byte
+
+	// SampleRate returns the codec's sample rate in Hz.
+	SampleRate() int
+
+	// FrameSize returns the number of wire-format bytes a single
+	// FrameDuration-length frame occupies for this codec.  Codecs with a
+	// variable-length wire format, such as Opus, return the signed-linear
+	// PCM size of one frame instead.
+	FrameSize() int
+}
@@ -0,0 +1,26 @@
+package codec
+
+import "testing"
+
+func TestFrameSize(t *testing.T) {
+	cases := []struct {
+		name string
+		c    Codec
+		want int
+	}{
+		{"ulaw", NewULaw(), 160},
+		{"alaw", NewALaw(), 160},
+		{"slin8", NewSlin8(), 320},
+		{"slin16", NewSlin16(), 640},
+		{"slin24", NewSlin24(), 960},
+		{"slin48", NewSlin48(), 1920},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.c.FrameSize(); got != tc.want {
+				t.Fatalf("FrameSize() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+//go:build opus
+
+package codec
+
+import (
+	"fmt"
+
+	"gopkg.in/hraban/opus.v2"
+)
+
+const opusKind = 0x16
+
+// Opus is the Opus codec, as used by integrators who prefer a compressed
+// wire format over raw signed-linear PCM.  It requires cgo and libopus, so
+// it is only built when the "opus" build tag is set:
+//
+//	go build -tags opus ./...
+type Opus struct {
+	sampleRate int
+	channels   int
+
+	enc *opus.Encoder
+	dec *opus.Decoder
+}
+
+// NewOpus returns a Codec implementing Opus at the given sample rate and
+// channel count.
+func NewOpus(sampleRate, channels int) (*Opus, error) {
+	enc, err := opus.NewEncoder(sampleRate, channels, opus.AppVoIP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create opus encoder: %w", err)
+	}
+
+	dec, err := opus.NewDecoder(sampleRate, channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create opus decoder: %w", err)
+	}
+
+	return &Opus{
+		sampleRate: sampleRate,
+		channels:   channels,
+		enc:        enc,
+		dec:        dec,
+	}, nil
+}
+
+// Kind returns the AudioSocket message kind byte for Opus frames.
+func (c *Opus) Kind() byte {
+	return opusKind
+}
+
+// SampleRate returns the configured Opus sample rate in Hz.
+func (c *Opus) SampleRate() int {
+	return c.sampleRate
+}
+
+// FrameSize returns the number of signed-linear PCM bytes one 20ms frame
+// occupies at the configured sample rate; the Opus-encoded frame itself is
+// variable length.
+func (c *Opus) FrameSize() int {
+	return int(FrameDuration.Seconds()*float64(c.sampleRate)) * 2 * c.channels
+}
+
+// Encode compresses one FrameDuration-length chunk of signed-linear PCM
+// into an Opus frame.
+func (c *Opus) Encode(pcm []byte) ([]byte, error) {
+	samples := make([]int16, len(pcm)/2)
+	for i := range samples {
+		samples[i] = int16(uint16(pcm[2*i]) | uint16(pcm[2*i+1])<<8)
+	}
+
+	data := make([]byte, 4000)
+	n, err := c.enc.Encode(samples, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode opus frame: %w", err)
+	}
+	return data[:n], nil
+}
+
+// Decode decompresses a single Opus frame into signed-linear PCM.
+func (c *Opus) Decode(frame []byte) ([]byte, error) {
+	samples := make([]int16, c.FrameSize()/2)
+	n, err := c.dec.Decode(frame, samples)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode opus frame: %w", err)
+	}
+
+	out := make([]byte, n*2*c.channels)
+	for i := 0; i < n*c.channels; i++ {
+		out[2*i] = byte(samples[i])
+		out[2*i+1] = byte(samples[i] >> 8)
+	}
+	return out, nil
+}
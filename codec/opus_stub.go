@@ -0,0 +1,16 @@
+//go:build !opus
+
+package codec
+
+import "errors"
+
+// ErrOpusNotBuilt is returned by NewOpus when the library was built without
+// the "opus" build tag (and therefore without its cgo dependency on
+// libopus).
+var ErrOpusNotBuilt = errors.New("codec: opus support not built in; build with -tags opus")
+
+// NewOpus returns ErrOpusNotBuilt.  Rebuild with the "opus" build tag to get
+// a working Opus codec; see opus.go.
+func NewOpus(sampleRate, channels int) (Codec, error) {
+	return nil, ErrOpusNotBuilt
+}
@@ -0,0 +1,65 @@
+package codec
+
+// Slin is a signed-linear PCM "codec" at a given sample rate.  Encode and
+// Decode are no-ops: slin is the format used everywhere else in this
+// library, so Slin exists only to carry a sample rate and AudioSocket Kind
+// for the higher rates Asterisk can now send (slin16, slin24, slin48)
+// through the generic Codec-based APIs.
+type Slin struct {
+	kind       byte
+	sampleRate int
+}
+
+const (
+	slin8Kind  = 0x10
+	slin16Kind = 0x13
+	slin24Kind = 0x14
+	slin48Kind = 0x15
+)
+
+// NewSlin8 returns a Codec tagging 8kHz signed-linear PCM, the default
+// format Asterisk's AudioSocket channel driver sends.
+func NewSlin8() *Slin {
+	return &Slin{kind: slin8Kind, sampleRate: 8000}
+}
+
+// NewSlin16 returns a Codec tagging 16kHz signed-linear PCM.
+func NewSlin16() *Slin {
+	return &Slin{kind: slin16Kind, sampleRate: 16000}
+}
+
+// NewSlin24 returns a Codec tagging 24kHz signed-linear PCM.
+func NewSlin24() *Slin {
+	return &Slin{kind: slin24Kind, sampleRate: 24000}
+}
+
+// NewSlin48 returns a Codec tagging 48kHz signed-linear PCM.
+func NewSlin48() *Slin {
+	return &Slin{kind: slin48Kind, sampleRate: 48000}
+}
+
+// Kind returns the AudioSocket message kind byte for this sample rate.
+func (c *Slin) Kind() byte {
+	return c.kind
+}
+
+// SampleRate returns the codec's sample rate in Hz.
+func (c *Slin) SampleRate() int {
+	return c.sampleRate
+}
+
+// FrameSize returns the number of bytes in one 20ms frame at this sample
+// rate (16-bit samples, so 2 bytes/sample).
+func (c *Slin) FrameSize() int {
+	return int(FrameDuration.Seconds()*float64(c.sampleRate)) * 2
+}
+
+// Encode returns pcm unmodified; slin is already signed-linear PCM.
+func (c *Slin) Encode(pcm []byte) ([]byte, error) {
+	return pcm, nil
+}
+
+// Decode returns frame unmodified; slin is already signed-linear PCM.
+func (c *Slin) Decode(frame []byte) ([]byte, error) {
+	return frame, nil
+}
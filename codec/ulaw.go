@@ -0,0 +1,100 @@
+package codec
+
+import "fmt"
+
+const (
+	uLawKind       = 0x11
+	uLawSampleRate = 8000
+	uLawBias       = 0x84
+	uLawClip       = 32635
+)
+
+// ULaw is the ITU-T G.711 mu-law codec, as used by Asterisk's AudioSocket
+// channel driver when the dialplan requests mu-law audio.
+type ULaw struct{}
+
+// NewULaw returns a Codec implementing G.711 mu-law.
+func NewULaw() *ULaw {
+	return &ULaw{}
+}
+
+// Kind returns the AudioSocket message kind byte for mu-law frames.
+func (c *ULaw) Kind() byte {
+	return uLawKind
+}
+
+// SampleRate returns the mu-law sample rate, which is always 8kHz.
+func (c *ULaw) SampleRate() int {
+	return uLawSampleRate
+}
+
+// FrameSize returns the number of wire bytes in one 20ms mu-law frame.
+func (c *ULaw) FrameSize() int {
+	return int(FrameDuration.Seconds() * float64(uLawSampleRate)) // 1 byte/sample
+}
+
+// Encode converts 16-bit signed-linear PCM into mu-law-encoded bytes.
+func (c *ULaw) Encode(pcm []byte) ([]byte, error) {
+	if len(pcm)%2 != 0 {
+		return nil, fmt.Errorf("pcm length %d is not a multiple of 2", len(pcm))
+	}
+
+	out := make([]byte, len(pcm)/2)
+	for i := range out {
+		sample := int16(uint16(pcm[2*i]) | uint16(pcm[2*i+1])<<8)
+		out[i] = linearToULaw(sample)
+	}
+	return out, nil
+}
+
+// Decode converts mu-law-encoded bytes into 16-bit signed-linear PCM.
+func (c *ULaw) Decode(frame []byte) ([]byte, error) {
+	out := make([]byte, len(frame)*2)
+	for i, b := range frame {
+		sample := uint16(uLawToLinear(b))
+		out[2*i] = byte(sample)
+		out[2*i+1] = byte(sample >> 8)
+	}
+	return out, nil
+}
+
+// linearToULaw encodes a single 16-bit linear sample to mu-law.
+func linearToULaw(sample int16) byte {
+	sign := byte(0x00)
+
+	s := int(sample)
+	if s < 0 {
+		s = -s
+		sign = 0x80
+	}
+	if s > uLawClip {
+		s = uLawClip
+	}
+	s += uLawBias
+
+	exponent := byte(7)
+	for expMask := 0x4000; s&expMask == 0 && exponent > 0; expMask >>= 1 {
+		exponent--
+	}
+
+	mantissa := byte(s>>(exponent+3)) & 0x0f
+
+	return ^(sign | exponent<<4 | mantissa)
+}
+
+// uLawToLinear decodes a single mu-law byte to a 16-bit linear sample.
+func uLawToLinear(b byte) int16 {
+	b = ^b
+
+	sign := b & 0x80
+	exponent := (b >> 4) & 0x07
+	mantissa := b & 0x0f
+
+	sample := (int(mantissa)<<3 + uLawBias) << exponent
+	sample -= uLawBias
+
+	if sign != 0 {
+		sample = -sample
+	}
+	return int16(sample)
+}
@@ -0,0 +1,221 @@
+// Package dtmf assembles individual DTMF digits, as delivered one at a time
+// on a Session's DTMF channel, into caller-meaningful input: fixed-length
+// codes, terminator-delimited entry, and inter-digit silence detection.
+package dtmf
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultDebounce is the window within which repeated, identical digits are
+// suppressed, to work around Asterisk emitting duplicate DTMF events for a
+// held key.
+const DefaultDebounce = 40 * time.Millisecond
+
+// ErrClosed is returned by ReadDigits and ReadUntil when the underlying
+// digit channel closes before the requested input is complete.
+var ErrClosed = errors.New("dtmf: collector closed")
+
+// Event records a single debounced digit and the time it was received, so
+// that callers can implement barge-in (e.g. cancel an in-progress audio send
+// as soon as the first digit arrives).
+type Event struct {
+	Digit rune
+	At    time.Time
+}
+
+// Collector subscribes to a Session's DTMF channel and assembles the
+// digits it delivers into caller-meaningful input.
+type Collector struct {
+	debounce time.Duration
+	created  time.Time
+	events   chan Event
+
+	mu       sync.Mutex
+	buf      []Event
+	consumed int
+	notify   chan struct{}
+	closed   bool
+}
+
+// NewCollector starts collecting digits from in.  If debounce is zero or
+// negative, DefaultDebounce is used.
+func NewCollector(in <-chan rune, debounce time.Duration) *Collector {
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	c := &Collector{
+		debounce: debounce,
+		created:  time.Now(),
+		events:   make(chan Event, 16),
+		notify:   make(chan struct{}),
+	}
+
+	go c.run(in)
+
+	return c
+}
+
+// Events returns a channel on which each debounced digit is delivered, in
+// addition to being buffered for ReadDigits/ReadUntil.  The channel holds up
+// to 16 undelivered events; a digit arriving while it is full is not sent on
+// it (it is still buffered for ReadDigits/ReadUntil).  It is intended for
+// barge-in: select on it alongside an audio send loop's completion to cancel
+// playback as soon as the caller presses a key.
+func (c *Collector) Events() <-chan Event {
+	return c.events
+}
+
+// ReadDigits blocks until n digits have been collected, ctx is done, or the
+// underlying channel closes, whichever comes first.
+func (c *Collector) ReadDigits(ctx context.Context, n int) (string, error) {
+	for {
+		c.mu.Lock()
+		if len(c.buf)-c.consumed >= n {
+			digits := c.take(n)
+			c.mu.Unlock()
+			return digits, nil
+		}
+		closed := c.closed
+		notify := c.notify
+		c.mu.Unlock()
+
+		if closed {
+			return "", ErrClosed
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// ReadUntil blocks until terminator is received, ctx is done, or the
+// underlying channel closes, whichever comes first.  The returned string
+// contains the digits collected before terminator; terminator itself is
+// consumed but not included.
+func (c *Collector) ReadUntil(ctx context.Context, terminator rune) (string, error) {
+	for {
+		c.mu.Lock()
+		if idx := c.indexOf(terminator); idx >= 0 {
+			digits := c.take(idx - c.consumed)
+			c.consumed++ // consume the terminator itself
+			c.mu.Unlock()
+			return digits, nil
+		}
+		closed := c.closed
+		notify := c.notify
+		c.mu.Unlock()
+
+		if closed {
+			return "", ErrClosed
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// WaitSilence blocks until interDigit has elapsed since the most recently
+// collected digit (or since the Collector was created, if none has arrived
+// yet), or the underlying channel closes.
+func (c *Collector) WaitSilence(interDigit time.Duration) {
+	for {
+		c.mu.Lock()
+		last := c.created
+		if len(c.buf) > 0 {
+			last = c.buf[len(c.buf)-1].At
+		}
+		closed := c.closed
+		notify := c.notify
+		c.mu.Unlock()
+
+		if closed {
+			return
+		}
+
+		remaining := interDigit - time.Since(last)
+		if remaining <= 0 {
+			return
+		}
+
+		select {
+		case <-notify:
+		case <-time.After(remaining):
+			return
+		}
+	}
+}
+
+// take returns the next n un-consumed digits as a string and advances the
+// consumed cursor.  Callers must hold c.mu.
+func (c *Collector) take(n int) string {
+	digits := make([]rune, n)
+	for i := 0; i < n; i++ {
+		digits[i] = c.buf[c.consumed+i].Digit
+	}
+	c.consumed += n
+	return string(digits)
+}
+
+// indexOf returns the buffer index of the next un-consumed occurrence of d,
+// or -1 if it has not been received.  Callers must hold c.mu.
+func (c *Collector) indexOf(d rune) int {
+	for i := c.consumed; i < len(c.buf); i++ {
+		if c.buf[i].Digit == d {
+			return i
+		}
+	}
+	return -1
+}
+
+// run reads digits from in until it closes, debouncing repeats that arrive
+// within c.debounce of the prior digit.
+func (c *Collector) run(in <-chan rune) {
+	var lastDigit rune
+	var lastTime time.Time
+
+	for d := range in {
+		now := time.Now()
+		if !lastTime.IsZero() && d == lastDigit && now.Sub(lastTime) < c.debounce {
+			continue
+		}
+		lastDigit = d
+		lastTime = now
+
+		ev := Event{Digit: d, At: now}
+		c.append(ev)
+
+		select {
+		case c.events <- ev:
+		default:
+		}
+	}
+
+	c.mu.Lock()
+	c.closed = true
+	old := c.notify
+	c.notify = make(chan struct{})
+	c.mu.Unlock()
+	close(old)
+	close(c.events)
+}
+
+// append adds ev to the buffer and wakes any blocked reader.
+func (c *Collector) append(ev Event) {
+	c.mu.Lock()
+	c.buf = append(c.buf, ev)
+	old := c.notify
+	c.notify = make(chan struct{})
+	c.mu.Unlock()
+	close(old)
+}
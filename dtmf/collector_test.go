@@ -0,0 +1,163 @@
+package dtmf
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestCollectorDebounceSuppressesRepeats verifies that an identical digit
+// arriving within the debounce window is suppressed, working around
+// Asterisk's tendency to emit duplicate DTMF events for a held key.
+func TestCollectorDebounceSuppressesRepeats(t *testing.T) {
+	const debounce = 50 * time.Millisecond
+
+	in := make(chan rune, 4)
+	c := NewCollector(in, debounce)
+
+	in <- '1'
+	time.Sleep(debounce / 4)
+	in <- '1' // within the debounce window: suppressed
+	close(in)
+
+	digits, err := c.ReadDigits(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ReadDigits failed: %v", err)
+	}
+	if digits != "1" {
+		t.Fatalf("ReadDigits = %q, want %q", digits, "1")
+	}
+
+	// The channel is closed and only one digit was ever buffered, so a
+	// second ReadDigits must see the closed collector rather than block.
+	if _, err := c.ReadDigits(context.Background(), 1); !errors.Is(err, ErrClosed) {
+		t.Fatalf("ReadDigits error = %v, want ErrClosed", err)
+	}
+}
+
+// TestCollectorDebouncePassesThroughAfterWindow verifies that an identical
+// digit arriving after the debounce window has elapsed is treated as a
+// distinct press, not suppressed.
+func TestCollectorDebouncePassesThroughAfterWindow(t *testing.T) {
+	const debounce = 20 * time.Millisecond
+
+	in := make(chan rune, 4)
+	c := NewCollector(in, debounce)
+
+	in <- '1'
+	time.Sleep(debounce * 3)
+	in <- '1' // outside the debounce window: distinct press
+	close(in)
+
+	digits, err := c.ReadDigits(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("ReadDigits failed: %v", err)
+	}
+	if digits != "11" {
+		t.Fatalf("ReadDigits = %q, want %q", digits, "11")
+	}
+}
+
+// TestCollectorReadUntilConsumesTerminator verifies that ReadUntil returns
+// the digits preceding the terminator and consumes the terminator itself,
+// so a subsequent read does not see it again.
+func TestCollectorReadUntilConsumesTerminator(t *testing.T) {
+	in := make(chan rune, 8)
+	c := NewCollector(in, time.Millisecond)
+
+	for _, d := range "123#456" {
+		in <- d
+	}
+	close(in)
+
+	digits, err := c.ReadUntil(context.Background(), '#')
+	if err != nil {
+		t.Fatalf("ReadUntil failed: %v", err)
+	}
+	if digits != "123" {
+		t.Fatalf("ReadUntil = %q, want %q", digits, "123")
+	}
+
+	rest, err := c.ReadDigits(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("ReadDigits failed: %v", err)
+	}
+	if rest != "456" {
+		t.Fatalf("ReadDigits = %q, want %q (terminator must not be re-returned)", rest, "456")
+	}
+}
+
+// TestCollectorReadDigitsContextCancel verifies that ReadDigits returns the
+// context's error as soon as it is canceled, rather than blocking forever
+// waiting for digits that never arrive.
+func TestCollectorReadDigitsContextCancel(t *testing.T) {
+	in := make(chan rune)
+	c := NewCollector(in, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.ReadDigits(ctx, 1)
+		done <- err
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("ReadDigits error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ReadDigits did not return after context cancellation")
+	}
+}
+
+// TestCollectorReadDigitsErrClosed verifies that ReadDigits unblocks with
+// ErrClosed once the source channel closes without delivering enough
+// digits, rather than blocking forever.
+func TestCollectorReadDigitsErrClosed(t *testing.T) {
+	in := make(chan rune, 1)
+	c := NewCollector(in, time.Millisecond)
+
+	in <- '1'
+	close(in)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.ReadDigits(context.Background(), 2)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("ReadDigits error = %v, want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ReadDigits did not return after source channel closed")
+	}
+}
+
+// TestCollectorWaitSilence verifies that WaitSilence blocks until interDigit
+// has elapsed since the last digit, and returns promptly once it has.
+func TestCollectorWaitSilence(t *testing.T) {
+	in := make(chan rune, 1)
+	c := NewCollector(in, time.Millisecond)
+
+	in <- '1'
+
+	const interDigit = 30 * time.Millisecond
+	start := time.Now()
+	c.WaitSilence(interDigit)
+	elapsed := time.Since(start)
+
+	if elapsed < interDigit/2 {
+		t.Fatalf("WaitSilence returned after %v, want at least ~%v", elapsed, interDigit)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("WaitSilence took %v, want it to return promptly once interDigit elapses", elapsed)
+	}
+}
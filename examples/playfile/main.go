@@ -2,9 +2,7 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"io"
 	"log"
 	"net"
 	"os"
@@ -18,13 +16,6 @@ const maxCallDuration = 2 * time.Minute
 
 const listenAddr = ":8080"
 
-// slinChunkSize is the number of bytes which should be sent per Slin
-// audiosocket message.  Larger data will be chunked into this size for
-// transmission of the AudioSocket.
-//
-// This is based on 8kHz, 20ms, 16-bit signed linear.
-const slinChunkSize = audiosocket.DefaultSlinChunkSize // 8000Hz * 20ms * 2 bytes
-
 var fileName string
 var audioData []byte
 
@@ -72,89 +63,81 @@ func Listen(ctx context.Context) error {
 	}
 }
 
-// Handle processes a call
+// Handle processes a call using the high-level Session API: NewSession reads
+// the initial ID message and starts dispatching the rest of the stream to
+// typed channels, so there is no hand-rolled NextMessage/switch loop here.
 func Handle(pCtx context.Context, c net.Conn) {
 	ctx, cancel := context.WithTimeout(pCtx, maxCallDuration)
+	defer cancel()
 
-	defer func() {
-		cancel()
-
-		if _, err := c.Write(audiosocket.HangupMessage()); err != nil {
-			log.Println("failed to send hangup message:", err)
-		}
-	}()
-
-	id, err := audiosocket.GetID(c)
+	s, err := audiosocket.NewSession(c)
 	if err != nil {
-		log.Println("failed to get call ID:", err)
+		log.Println("failed to establish session:", err)
 		return
 	}
-	log.Printf("processing call %s", id.String())
+	defer s.Close()
+
+	log.Printf("processing call %s", s.ID())
 
-	go processDataFromAsterisk(ctx, c)
+	go logEvents(ctx, s)
 
 	log.Println("sending audio")
-	if err = sendAudio(ctx, c, audioData); err != nil {
+	if err := sendAudio(ctx, s, audioData); err != nil {
 		log.Println("failed to send audio to Asterisk:", err)
 	}
 	log.Println("completed audio send")
-}
 
-func processDataFromAsterisk(ctx context.Context, in io.Reader) {
-	var err error
-	var m audiosocket.Message
+	if err := s.SendHangup(); err != nil {
+		log.Println("failed to send hangup message:", err)
+	}
+}
 
-	for ctx.Err() == nil {
-		m, err = audiosocket.NextMessage(in)
-		if errors.Is(err, io.EOF) {
-			log.Println("audiosocket closed")
+// logEvents ranges over a Session's typed channels, logging whatever
+// arrives, until the Session ends or ctx is done.
+func logEvents(ctx context.Context, s *audiosocket.Session) {
+	for {
+		select {
+		case <-ctx.Done():
 			return
-		}
-		switch m.Kind() {
-		case audiosocket.KindHangup:
+		case _, ok := <-s.Hangup():
+			if !ok {
+				return
+			}
 			log.Println("audiosocket received hangup command")
 			return
-		case audiosocket.KindError:
-			log.Println("error from audiosocket")
-		case audiosocket.KindDTMF:
-			log.Println("received DTMF: ", string(m.Payload()))
-		case audiosocket.KindSlin:
-			if m.ContentLength() < 1 {
+		case d, ok := <-s.DTMF():
+			if !ok {
+				return
+			}
+			log.Printf("received DTMF: %c", d)
+		case code, ok := <-s.Errors():
+			if !ok {
+				return
+			}
+			log.Println("error from audiosocket:", code)
+		case payload, ok := <-s.Audio():
+			if !ok {
+				return
+			}
+			if len(payload) < 1 {
 				log.Println("no audio data")
 			}
-			// m.Payload() contains the received audio bytes
-		default:
+			// payload contains the received, signed-linear audio bytes
 		}
 	}
 }
 
-func sendAudio(ctx context.Context, w io.Writer, data []byte) error {
-	var i, chunks int
-
-	t := time.NewTicker(20 * time.Millisecond)
-	defer t.Stop()
-
-	for range t.C {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		if i >= len(data) {
-			return nil
-		}
+// sendAudio sends data to the Session, stopping early if ctx is done.
+func sendAudio(ctx context.Context, s *audiosocket.Session, data []byte) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- s.SendAudio(data)
+	}()
 
-		var chunkLen = slinChunkSize
-		if i+slinChunkSize > len(data) {
-			chunkLen = len(data) - i
-		}
-		if _, err := w.Write(audiosocket.SlinMessage(data[i : i+chunkLen])); err != nil {
-			return fmt.Errorf("failed to write chunk to audiosocket: %w", err)
-		}
-		chunks++
-		i += chunkLen
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-done:
+		return err
 	}
-
-	return errors.New("ticker unexpectedly stopped")
 }
@@ -0,0 +1,92 @@
+package audiosocket
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/CyCoreSystems/audiosocket/codec"
+)
+
+// Pacer sends chunks to an io.Writer at a steady real-time rate.  Each
+// chunk's deadline is computed from a fixed start time and the number of
+// chunks already sent, rather than accumulated tick-by-tick from a
+// time.Ticker, so a late chunk (e.g. following a GC pause or scheduler
+// delay) does not push every subsequent chunk later by the same amount:
+// the next deadline is still measured from start, so the Pacer catches back
+// up instead of drifting.
+type Pacer struct {
+	w             io.Writer
+	chunkDuration time.Duration
+
+	start      time.Time
+	chunksSent int
+}
+
+// NewPacer returns a Pacer which writes to w, sending chunks at
+// chunkDuration intervals measured from now.
+func NewPacer(w io.Writer, chunkDuration time.Duration) *Pacer {
+	return &Pacer{w: w, chunkDuration: chunkDuration, start: time.Now()}
+}
+
+// Send blocks, if necessary, until this chunk's scheduled deadline, then
+// writes it to the underlying Writer.
+func (p *Pacer) Send(chunk []byte) error {
+	deadline := p.start.Add(time.Duration(p.chunksSent) * p.chunkDuration)
+	if d := time.Until(deadline); d > 0 {
+		time.Sleep(d)
+	}
+	p.chunksSent++
+
+	if _, err := p.w.Write(chunk); err != nil {
+		return fmt.Errorf("failed to write paced chunk: %w", err)
+	}
+	return nil
+}
+
+// pacedWriter adapts a Pacer to an io.Writer, chunking arbitrary PCM writes
+// at its codec's frame boundary.
+type pacedWriter struct {
+	pacer *Pacer
+	codec codec.Codec
+	buf   []byte
+}
+
+// Paced returns an io.Writer which encodes arbitrary signed-linear PCM
+// writes using c, chunks them into codec.FrameDuration-length frames, wraps each chunk in
+// an AudioMessage, and paces the result to w in real time.  A caller can
+// io.Copy raw PCM into the result (e.g. from a WAV reader) and get correct
+// real-time playout without writing a send loop.
+func Paced(w io.Writer, c codec.Codec) io.Writer {
+	return &pacedWriter{
+		pacer: NewPacer(w, codec.FrameDuration),
+		codec: c,
+	}
+}
+
+// Write buffers p, sending as many complete frames as are available and
+// retaining any remainder for the next call.  On success it reports the full
+// length of p as written; if encoding or sending a frame fails, it returns 0
+// and that error.
+func (pw *pacedWriter) Write(p []byte) (int, error) {
+	pw.buf = append(pw.buf, p...)
+
+	frameSize := pcmFrameSize(pw.codec)
+
+	for len(pw.buf) >= frameSize {
+		encoded, err := pw.codec.Encode(pw.buf[:frameSize])
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode paced frame: %w", err)
+		}
+
+		if err := pw.pacer.Send(AudioMessage(Kind(pw.codec.Kind()), encoded)); err != nil {
+			return 0, err
+		}
+
+		// Drop the frame we just sent so a later failure in this same
+		// Write call does not cause it to be resent.
+		pw.buf = pw.buf[:copy(pw.buf, pw.buf[frameSize:])]
+	}
+
+	return len(p), nil
+}
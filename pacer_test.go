@@ -0,0 +1,108 @@
+package audiosocket
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/CyCoreSystems/audiosocket/codec"
+)
+
+// timestampWriter records the time at which each Write call arrives.
+type timestampWriter struct {
+	times []time.Time
+}
+
+func (w *timestampWriter) Write(p []byte) (int, error) {
+	w.times = append(w.times, time.Now())
+	return len(p), nil
+}
+
+// TestPacerJitterUnderSimulatedStalls verifies that a Pacer, which schedules
+// each send from a fixed start time rather than accumulating ticks,
+// recovers from an occasional scheduler/GC-style stall instead of letting
+// the delay compound into every subsequent send.
+func TestPacerJitterUnderSimulatedStalls(t *testing.T) {
+	const (
+		chunkDuration = 5 * time.Millisecond
+		numChunks     = 600
+	)
+
+	// stalls simulates a goroutine being starved of CPU time (e.g. by a GC
+	// pause) immediately before it calls Send.
+	stalls := map[int]time.Duration{
+		150: 8 * time.Millisecond,
+		300: 8 * time.Millisecond,
+		450: 8 * time.Millisecond,
+	}
+
+	w := &timestampWriter{}
+	p := NewPacer(w, chunkDuration)
+	start := p.start
+
+	for i := 0; i < numChunks; i++ {
+		if stall, ok := stalls[i]; ok {
+			time.Sleep(stall)
+		}
+		if err := p.Send([]byte("x")); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+
+	if len(w.times) != numChunks {
+		t.Fatalf("expected %d writes, got %d", numChunks, len(w.times))
+	}
+
+	var sumSq float64
+	for i, got := range w.times {
+		expected := start.Add(time.Duration(i) * chunkDuration)
+		jitter := got.Sub(expected).Seconds() * 1000 // milliseconds
+		sumSq += jitter * jitter
+	}
+	rms := math.Sqrt(sumSq / float64(numChunks))
+
+	const maxRMS = 2.0 // milliseconds
+	if rms > maxRMS {
+		t.Fatalf("jitter RMS %.3fms exceeds %.3fms", rms, maxRMS)
+	}
+}
+
+// TestPacedTerminates guards against a regression where a codec's
+// FrameSize() returning 0 made pacedWriter.Write's chunking loop spin
+// forever instead of draining pw.buf.
+func TestPacedTerminates(t *testing.T) {
+	c := codec.NewSlin8()
+	const numFrames = 5
+	pcm := make([]byte, c.FrameSize()*numFrames+3) // plus a short final frame
+
+	var buf bytes.Buffer
+	paced := Paced(&buf, c)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(paced, bytes.NewReader(pcm))
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("io.Copy into Paced returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Paced did not drain within timeout; FrameSize() is likely 0 again")
+	}
+
+	wantFrames := numFrames // the short final frame is buffered, not sent
+	gotFrames := 0
+	for b := buf.Bytes(); len(b) > 0; {
+		payloadLen := int(b[1])<<8 | int(b[2])
+		b = b[3+payloadLen:]
+		gotFrames++
+	}
+	if gotFrames != wantFrames {
+		t.Fatalf("got %d frames, want %d", gotFrames, wantFrames)
+	}
+}
@@ -0,0 +1,134 @@
+package audiosocket
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// MaxPayloadSize is the default maximum payload length, in bytes, a Reader
+// will accept before returning ErrFrameTooLarge.  This matches the largest
+// value the 16-bit length field can encode, so by default a Reader accepts
+// anything a conforming peer could send.
+const MaxPayloadSize = 65535
+
+// ErrFrameTooLarge is returned by Reader.ReadMessage when a peer's claimed
+// payload length exceeds the maximum allowed for its Kind, so the payload
+// is rejected before it is allocated.
+var ErrFrameTooLarge = errors.New("audiosocket: frame exceeds maximum payload size")
+
+// kindMaxPayloadSize returns the maximum acceptable payload size for kind.
+// Kinds with a protocol-defined size are bounded tightly regardless of max;
+// everything else (notably KindSlin and the other audio kinds) is bounded
+// by max.
+func kindMaxPayloadSize(kind Kind, max int) int {
+	switch kind {
+	case KindHangup, KindSilence:
+		return 0
+	case KindDTMF, KindError:
+		return 1
+	case KindID:
+		return 16
+	default:
+		return max
+	}
+}
+
+// bufferPool holds reusable Message buffers for callers that do not want to
+// manage their own across ReadMessage calls; see GetBuffer.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return make(Message, 0, MaxPayloadSize+3) },
+}
+
+// GetBuffer returns a zero-length Message buffer from a shared pool, sized
+// to avoid reallocation for any valid frame.  Use it as the dst argument to
+// Reader.ReadMessage, and return it afterward with PutBuffer.
+func GetBuffer() Message {
+	return bufferPool.Get().(Message)[:0]
+}
+
+// PutBuffer returns a buffer obtained from GetBuffer to the shared pool.
+func PutBuffer(m Message) {
+	bufferPool.Put(m) //nolint:staticcheck // intentionally pooling a slice
+}
+
+// Reader reads AudioSocket messages from an underlying io.Reader.  Unlike
+// the package-level NextMessage, ReadMessage can reuse a caller-supplied
+// buffer across calls, avoiding a per-message allocation on long-running
+// connections, and rejects oversized frames before allocating their
+// payload.
+type Reader struct {
+	r   io.Reader
+	hdr [3]byte
+
+	// MaxPayloadSize overrides MaxPayloadSize as the bound applied to
+	// message kinds without a tighter, protocol-defined size. Zero means
+	// MaxPayloadSize.
+	MaxPayloadSize int
+
+	// ReadTimeout, if non-zero, is applied as a read deadline before each
+	// message is read, via SetReadDeadline on the net.Conn passed to
+	// NewReader.  It is a no-op if r is not a net.Conn. Use ReadDeadline
+	// to set it.
+	ReadTimeout time.Duration
+}
+
+// NewReader returns a Reader which reads AudioSocket messages from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r}
+}
+
+// ReadDeadline sets d as the Reader's ReadTimeout, so that a stalled peer
+// cannot pin the reading goroutine forever.  It is a no-op unless the
+// Reader was constructed over a net.Conn.
+func (rd *Reader) ReadDeadline(d time.Duration) {
+	rd.ReadTimeout = d
+}
+
+// ReadMessage reads the next message from the connection.  If dst has
+// sufficient capacity to hold it, dst's backing array is reused for the
+// returned Message; otherwise a new one is allocated.
+func (rd *Reader) ReadMessage(dst Message) (Message, error) {
+	if rd.ReadTimeout > 0 {
+		if c, ok := rd.r.(net.Conn); ok {
+			if err := c.SetReadDeadline(time.Now().Add(rd.ReadTimeout)); err != nil {
+				return nil, fmt.Errorf("failed to set read deadline: %w", err)
+			}
+		}
+	}
+
+	if _, err := io.ReadFull(rd.r, rd.hdr[:]); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	kind := Kind(rd.hdr[0])
+	payloadLen := int(binary.BigEndian.Uint16(rd.hdr[1:]))
+
+	max := rd.MaxPayloadSize
+	if max <= 0 {
+		max = MaxPayloadSize
+	}
+	if payloadLen > kindMaxPayloadSize(kind, max) {
+		return nil, fmt.Errorf("%w: kind %d, size %d", ErrFrameTooLarge, kind, payloadLen)
+	}
+
+	total := 3 + payloadLen
+	if cap(dst) >= total {
+		dst = dst[:total]
+	} else {
+		dst = make(Message, total)
+	}
+	copy(dst[:3], rd.hdr[:])
+
+	if payloadLen > 0 {
+		if _, err := io.ReadFull(rd.r, dst[3:total]); err != nil {
+			return nil, fmt.Errorf("failed to read payload: %w", err)
+		}
+	}
+
+	return dst, nil
+}
@@ -0,0 +1,88 @@
+package audiosocket
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+// TestReaderReusesBuffer verifies that ReadMessage reuses dst's backing
+// array when it has sufficient capacity, rather than allocating a new one.
+func TestReaderReusesBuffer(t *testing.T) {
+	var wire bytes.Buffer
+	wire.Write(SlinMessage([]byte{1, 2, 3, 4}))
+	wire.Write(SlinMessage([]byte{5, 6}))
+
+	rd := NewReader(&wire)
+
+	first, err := rd.ReadMessage(nil)
+	if err != nil {
+		t.Fatalf("first ReadMessage failed: %v", err)
+	}
+	firstPtr := &first[:cap(first)][0]
+
+	second, err := rd.ReadMessage(first)
+	if err != nil {
+		t.Fatalf("second ReadMessage failed: %v", err)
+	}
+	secondPtr := &second[:cap(second)][0]
+
+	if firstPtr != secondPtr {
+		t.Fatal("ReadMessage did not reuse dst's backing array")
+	}
+	if !bytes.Equal(second.Payload(), []byte{5, 6}) {
+		t.Fatalf("second message payload = %v, want [5 6]", second.Payload())
+	}
+}
+
+// TestReaderFrameTooLarge verifies that a payload length exceeding the
+// maximum allowed for its Kind is rejected with ErrFrameTooLarge before the
+// payload is allocated, rather than trusting the wire's length field.
+func TestReaderFrameTooLarge(t *testing.T) {
+	var wire bytes.Buffer
+	// KindDTMF's protocol-defined max payload size is 1 byte; claim 2.
+	wire.Write([]byte{KindDTMF, 0x00, 0x02, '1', '2'})
+
+	rd := NewReader(&wire)
+
+	if _, err := rd.ReadMessage(nil); !errors.Is(err, ErrFrameTooLarge) {
+		t.Fatalf("ReadMessage error = %v, want ErrFrameTooLarge", err)
+	}
+}
+
+// TestReaderMaxPayloadSize verifies that MaxPayloadSize overrides the
+// default bound applied to kinds without a protocol-defined size.
+func TestReaderMaxPayloadSize(t *testing.T) {
+	var wire bytes.Buffer
+	wire.Write(SlinMessage([]byte{1, 2, 3, 4}))
+
+	rd := NewReader(&wire)
+	rd.MaxPayloadSize = 2
+
+	if _, err := rd.ReadMessage(nil); !errors.Is(err, ErrFrameTooLarge) {
+		t.Fatalf("ReadMessage error = %v, want ErrFrameTooLarge", err)
+	}
+}
+
+// TestReaderReadDeadline verifies that ReadDeadline applies a read deadline
+// to an underlying net.Conn, so a stalled peer cannot pin the reading
+// goroutine forever.
+func TestReaderReadDeadline(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	rd := NewReader(server)
+	rd.ReadDeadline(20 * time.Millisecond)
+
+	start := time.Now()
+	_, err := rd.ReadMessage(nil)
+	if err == nil {
+		t.Fatal("ReadMessage succeeded, want a deadline-exceeded error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("ReadMessage took %v to fail, want it to respect the read deadline", elapsed)
+	}
+}
@@ -0,0 +1,101 @@
+//go:build lame
+
+package recorder
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/viert/lame"
+
+	"github.com/CyCoreSystems/audiosocket"
+)
+
+// MP3Options configures an MP3Recorder.
+type MP3Options struct {
+	// SampleRate is the input PCM sample rate in Hz.  Defaults to 8000.
+	SampleRate int
+
+	// Bitrate is the output MP3 bitrate in kbps.  Defaults to 32.
+	Bitrate int
+}
+
+// MP3Recorder writes signed-linear PCM audio to an io.Writer, LAME-encoded
+// as MP3.  It requires cgo and libmp3lame, so it is only built when the
+// "lame" build tag is set:
+//
+//	go build -tags lame ./...
+type MP3Recorder struct {
+	w          io.Writer
+	enc        *lame.LameWriter
+	sampleRate int
+	written    int64
+}
+
+// NewMP3Recorder wraps go-lame to write audio to w as MP3.
+func NewMP3Recorder(w io.Writer, opts MP3Options) (*MP3Recorder, error) {
+	if opts.SampleRate < 1 {
+		opts.SampleRate = 8000
+	}
+	if opts.Bitrate < 1 {
+		opts.Bitrate = 32
+	}
+
+	enc := lame.NewWriter(w)
+	enc.Encoder.SetInSamplerate(opts.SampleRate)
+	enc.Encoder.SetNumChannels(1)
+	enc.Encoder.SetBitrate(opts.Bitrate)
+	if rc := enc.Encoder.InitParams(); rc != 0 {
+		return nil, fmt.Errorf("failed to initialize MP3 encoder: return code %d", rc)
+	}
+
+	return &MP3Recorder{w: w, enc: enc, sampleRate: opts.SampleRate}, nil
+}
+
+// Write appends raw signed-linear PCM audio to the recording.
+func (r *MP3Recorder) Write(pcm []byte) (int, error) {
+	n, err := r.enc.Write(pcm)
+	r.written += int64(n)
+	if err != nil {
+		return n, fmt.Errorf("failed to write PCM data to MP3 encoder: %w", err)
+	}
+	return n, nil
+}
+
+// WriteMessage consumes one message from the inbound AudioSocket stream.
+func (r *MP3Recorder) WriteMessage(m audiosocket.Message) error {
+	switch m.Kind() {
+	case audiosocket.KindSlin:
+		if m.ContentLength() < 1 {
+			return nil
+		}
+		_, err := r.Write(m.Payload())
+		return err
+	case audiosocket.KindSilence:
+		_, err := r.Write(silenceFrame(r.sampleRate))
+		return err
+	default:
+		return nil
+	}
+}
+
+// Duration returns the duration of audio written so far.
+func (r *MP3Recorder) Duration() time.Duration {
+	const bytesPerSample = 2
+	samples := r.written / bytesPerSample
+	return time.Duration(samples) * time.Second / time.Duration(r.sampleRate)
+}
+
+// BytesWritten returns the number of PCM bytes written so far.
+func (r *MP3Recorder) BytesWritten() int64 {
+	return r.written
+}
+
+// Close flushes the LAME encoder's buffered state.
+func (r *MP3Recorder) Close() error {
+	if err := r.enc.Close(); err != nil {
+		return fmt.Errorf("failed to close MP3 encoder: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+//go:build !lame
+
+package recorder
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrMP3NotBuilt is returned by NewMP3Recorder when the library was built
+// without the "lame" build tag (and therefore without its cgo dependency on
+// libmp3lame).
+var ErrMP3NotBuilt = errors.New("recorder: MP3 support not built in; build with -tags lame")
+
+// MP3Options configures an MP3Recorder.  See mp3.go for the built
+// definition.
+type MP3Options struct {
+	SampleRate int
+	Bitrate    int
+}
+
+// NewMP3Recorder returns ErrMP3NotBuilt.  Rebuild with the "lame" build tag
+// to get a working MP3 recorder; see mp3.go.
+func NewMP3Recorder(w io.Writer, opts MP3Options) (Recorder, error) {
+	return nil, ErrMP3NotBuilt
+}
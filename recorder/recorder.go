@@ -0,0 +1,43 @@
+// Package recorder consumes an inbound AudioSocket audio stream and writes
+// it to an io.Writer in a properly-headed container (WAV, MP3), so that
+// callers do not need to reimplement container framing for the common
+// "record the caller" use case.
+package recorder
+
+import (
+	"time"
+
+	"github.com/CyCoreSystems/audiosocket"
+)
+
+// DefaultFrameDuration is the AudioSocket frame interval assumed when
+// synthesizing silence, matching audiosocket.DefaultSlinChunkSize.
+const DefaultFrameDuration = 20 * time.Millisecond
+
+// Recorder consumes messages from an inbound AudioSocket audio stream and
+// writes their audio content to an underlying container.
+type Recorder interface {
+	// WriteMessage consumes one message from the inbound AudioSocket
+	// stream.  KindSlin payloads are appended as-is; KindSilence messages
+	// insert zeroed PCM of DefaultFrameDuration so that timing is
+	// preserved.  Any other Kind is ignored.
+	WriteMessage(m audiosocket.Message) error
+
+	// Duration returns the duration of audio written so far.
+	Duration() time.Duration
+
+	// BytesWritten returns the number of PCM bytes written so far.
+	BytesWritten() int64
+
+	// Close finalizes the container (backpatching any header fields that
+	// depend on the final length) and, if the underlying writer requires
+	// it, flushes any buffered encoder state.
+	Close() error
+}
+
+// silenceFrame returns a zeroed PCM frame of DefaultFrameDuration at the
+// given sample rate (16-bit mono samples).
+func silenceFrame(sampleRate int) []byte {
+	n := sampleRate * 2 * int(DefaultFrameDuration.Milliseconds()) / 1000
+	return make([]byte, n)
+}
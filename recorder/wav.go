@@ -0,0 +1,141 @@
+package recorder
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/CyCoreSystems/audiosocket"
+)
+
+// wavHeaderSize is the size, in bytes, of the canonical 44-byte PCM RIFF/WAVE
+// header this package writes.
+const wavHeaderSize = 44
+
+// WAVRecorder writes signed-linear PCM audio to an io.Writer inside a
+// RIFF/WAVE container.
+type WAVRecorder struct {
+	w          io.Writer
+	sampleRate int
+	written    int64
+}
+
+// NewWAVRecorder writes a WAV header to w and returns a Recorder which
+// appends signed-linear, 16-bit mono PCM audio to it as messages arrive.
+//
+// If w also implements io.Seeker, the RIFF and data chunk sizes are
+// backpatched in place on Close, once the final length is known.
+// Otherwise, the sizes are left at the streaming-WAV convention of
+// 0xFFFFFFFF ("length unknown"), since w cannot be rewound.
+func NewWAVRecorder(w io.Writer, sampleRate int) (*WAVRecorder, error) {
+	r := &WAVRecorder{w: w, sampleRate: sampleRate}
+
+	if err := writeWAVHeader(w, sampleRate, 0xFFFFFFFF); err != nil {
+		return nil, fmt.Errorf("failed to write WAV header: %w", err)
+	}
+
+	return r, nil
+}
+
+// Write appends raw signed-linear PCM audio to the recording.
+func (r *WAVRecorder) Write(pcm []byte) (int, error) {
+	n, err := r.w.Write(pcm)
+	r.written += int64(n)
+	if err != nil {
+		return n, fmt.Errorf("failed to write PCM data: %w", err)
+	}
+	return n, nil
+}
+
+// WriteMessage consumes one message from the inbound AudioSocket stream.
+func (r *WAVRecorder) WriteMessage(m audiosocket.Message) error {
+	switch m.Kind() {
+	case audiosocket.KindSlin:
+		if m.ContentLength() < 1 {
+			return nil
+		}
+		_, err := r.Write(m.Payload())
+		return err
+	case audiosocket.KindSilence:
+		_, err := r.Write(silenceFrame(r.sampleRate))
+		return err
+	default:
+		return nil
+	}
+}
+
+// Duration returns the duration of audio written so far.
+func (r *WAVRecorder) Duration() time.Duration {
+	const bytesPerSample = 2
+	samples := r.written / bytesPerSample
+	return time.Duration(samples) * time.Second / time.Duration(r.sampleRate)
+}
+
+// BytesWritten returns the number of PCM bytes written so far.
+func (r *WAVRecorder) BytesWritten() int64 {
+	return r.written
+}
+
+// Close backpatches the header with the final chunk sizes if w supports
+// seeking.
+func (r *WAVRecorder) Close() error {
+	seeker, ok := r.w.(io.Seeker)
+	if !ok {
+		return nil
+	}
+
+	if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek to start of WAV file: %w", err)
+	}
+
+	if err := writeWAVHeader(r.w, r.sampleRate, uint32(r.written)); err != nil {
+		return fmt.Errorf("failed to backpatch WAV header: %w", err)
+	}
+
+	if _, err := seeker.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("failed to seek to end of WAV file: %w", err)
+	}
+
+	return nil
+}
+
+// writeWAVHeader writes a canonical 44-byte PCM WAV header for 16-bit mono
+// audio at sampleRate, with a data chunk of dataSize bytes.
+func writeWAVHeader(w io.Writer, sampleRate int, dataSize uint32) error {
+	const (
+		numChannels   = 1
+		bitsPerSample = 16
+	)
+
+	byteRate := sampleRate * numChannels * bitsPerSample / 8
+	blockAlign := numChannels * bitsPerSample / 8
+
+	hdr := make([]byte, wavHeaderSize)
+
+	riffSize := dataSize + wavHeaderSize - 8
+	if dataSize == 0xFFFFFFFF {
+		// Keep the streaming "length unknown" sentinel rather than letting
+		// it overflow uint32 arithmetic.
+		riffSize = 0xFFFFFFFF
+	}
+
+	copy(hdr[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(hdr[4:8], riffSize)
+	copy(hdr[8:12], "WAVE")
+
+	copy(hdr[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(hdr[16:20], 16) // fmt chunk size
+	binary.LittleEndian.PutUint16(hdr[20:22], 1)  // PCM
+	binary.LittleEndian.PutUint16(hdr[22:24], numChannels)
+	binary.LittleEndian.PutUint32(hdr[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(hdr[28:32], uint32(byteRate))
+	binary.LittleEndian.PutUint16(hdr[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(hdr[34:36], bitsPerSample)
+
+	copy(hdr[36:40], "data")
+	binary.LittleEndian.PutUint32(hdr[40:44], dataSize)
+
+	_, err := w.Write(hdr)
+	return err
+}
@@ -0,0 +1,23 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// TestWriteWAVHeaderStreamingSentinel verifies that the streaming "length
+// unknown" sentinel (0xFFFFFFFF) used when the underlying writer is not an
+// io.Seeker is preserved in the RIFF chunk size rather than overflowing
+// uint32 arithmetic.
+func TestWriteWAVHeaderStreamingSentinel(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeWAVHeader(&buf, 8000, 0xFFFFFFFF); err != nil {
+		t.Fatalf("writeWAVHeader failed: %v", err)
+	}
+
+	riffSize := binary.LittleEndian.Uint32(buf.Bytes()[4:8])
+	if riffSize != 0xFFFFFFFF {
+		t.Fatalf("RIFF chunk size = 0x%x, want 0xFFFFFFFF", riffSize)
+	}
+}
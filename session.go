@@ -0,0 +1,272 @@
+package audiosocket
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CyCoreSystems/audiosocket/codec"
+)
+
+// sessionChannelBuffer is the number of pending items each Session channel
+// will buffer before the reader goroutine blocks waiting on a consumer.
+const sessionChannelBuffer = 16
+
+// Session wraps a net.Conn carrying an AudioSocket stream.  On creation, it
+// reads the initial ID message and then runs a reader goroutine which
+// dispatches each subsequent message to the appropriate typed channel.  All
+// channels are closed when the call hangs up or the connection is lost, so a
+// consumer can simply range over them rather than checking for EOF itself.
+type Session struct {
+	conn net.Conn
+	id   uuid.UUID
+
+	codecMu sync.RWMutex
+	codec   codec.Codec
+
+	reader *Reader
+
+	audioCh  chan []byte
+	dtmfCh   chan rune
+	hangupCh chan struct{}
+	errCh    chan ErrorCode
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewSession reads the initial ID message from c and returns a Session which
+// dispatches the remainder of the AudioSocket stream to typed channels.  The
+// returned Session owns c; closing the Session closes c.
+func NewSession(c net.Conn) (*Session, error) {
+	id, err := GetID(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read call ID: %w", err)
+	}
+
+	s := &Session{
+		conn:     c,
+		id:       id,
+		reader:   NewReader(c),
+		audioCh:  make(chan []byte, sessionChannelBuffer),
+		dtmfCh:   make(chan rune, sessionChannelBuffer),
+		hangupCh: make(chan struct{}),
+		errCh:    make(chan ErrorCode, sessionChannelBuffer),
+		done:     make(chan struct{}),
+	}
+
+	go s.run()
+
+	return s, nil
+}
+
+// ID returns the unique ID of the call carried by this Session.
+func (s *Session) ID() uuid.UUID {
+	return s.id
+}
+
+// Audio returns the channel on which received signed-linear audio payloads
+// are delivered.  The channel is closed when the Session ends.
+func (s *Session) Audio() <-chan []byte {
+	return s.audioCh
+}
+
+// DTMF returns the channel on which received DTMF digits are delivered.  The
+// channel is closed when the Session ends.
+func (s *Session) DTMF() <-chan rune {
+	return s.dtmfCh
+}
+
+// Hangup returns a channel which is closed when the remote end signals
+// hangup or the connection is otherwise lost.
+func (s *Session) Hangup() <-chan struct{} {
+	return s.hangupCh
+}
+
+// Errors returns the channel on which received error codes are delivered.
+// The channel is closed when the Session ends.
+func (s *Session) Errors() <-chan ErrorCode {
+	return s.errCh
+}
+
+// SetCodec configures c as the codec used to decode inbound audio
+// (delivered on the Audio channel) and to encode outbound audio sent via
+// SendAudio.  By default, a Session assumes signed-linear audio and performs
+// no transcoding.
+func (s *Session) SetCodec(c codec.Codec) {
+	s.codecMu.Lock()
+	s.codec = c
+	s.codecMu.Unlock()
+}
+
+// SetReadTimeout sets d as a read deadline applied before each inbound
+// message, so that a stalled connection does not pin the Session's reader
+// goroutine forever. By default, a Session applies no read deadline. See
+// Reader.ReadDeadline.
+func (s *Session) SetReadTimeout(d time.Duration) {
+	s.reader.ReadDeadline(d)
+}
+
+// SendSlin sends a chunk of signed-linear audio data to the Session.
+func (s *Session) SendSlin(pcm []byte) error {
+	if _, err := s.conn.Write(SlinMessage(pcm)); err != nil {
+		return fmt.Errorf("failed to send slin message: %w", err)
+	}
+	return nil
+}
+
+// SendAudio encodes pcm using the codec configured via SetCodec (signed-
+// linear, if none has been set) and sends it to the Session, chunking at the
+// codec's natural frame size.
+func (s *Session) SendAudio(pcm []byte) error {
+	s.codecMu.RLock()
+	c := s.codec
+	s.codecMu.RUnlock()
+
+	if c == nil {
+		return SendAudio(s.conn, codec.NewSlin8(), pcm)
+	}
+	return SendAudio(s.conn, c, pcm)
+}
+
+// SendHangup sends a hangup message to the Session.
+func (s *Session) SendHangup() error {
+	if _, err := s.conn.Write(HangupMessage()); err != nil {
+		return fmt.Errorf("failed to send hangup message: %w", err)
+	}
+	return nil
+}
+
+// Close stops the reader goroutine and closes the underlying connection.
+// It is safe to call Close more than once.
+func (s *Session) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+	})
+	return s.conn.Close()
+}
+
+// run reads messages from the connection until hangup, EOF, or a fatal
+// error, dispatching each to its typed channel.  All channels are closed
+// before run returns, guaranteeing that consumers ranging over them will
+// terminate.
+func (s *Session) run() {
+	defer s.closeChannels()
+
+	var buf Message
+
+	for {
+		m, err := s.reader.ReadMessage(buf)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				select {
+				case s.errCh <- ErrUnknown:
+				case <-s.done:
+				}
+			}
+			return
+		}
+
+		switch m.Kind() {
+		case KindHangup:
+			return
+		case KindSlin, KindULaw, KindALaw, KindSlin16, KindSlin24, KindSlin48, KindOpus:
+			if m.ContentLength() < 1 {
+				continue
+			}
+
+			payload, err := s.decodeAudio(m.Kind(), m.Payload())
+			if err != nil {
+				select {
+				case s.errCh <- ErrUnknown:
+				case <-s.done:
+					return
+				}
+				continue
+			}
+
+			select {
+			case s.audioCh <- payload:
+			case <-s.done:
+				return
+			}
+		case KindDTMF:
+			if m.ContentLength() < 1 {
+				continue
+			}
+			select {
+			case s.dtmfCh <- rune(m.Payload()[0]):
+			case <-s.done:
+				return
+			}
+		case KindError:
+			select {
+			case s.errCh <- m.ErrorCode():
+			case <-s.done:
+				return
+			}
+		case KindSilence, KindID:
+			// nothing to dispatch
+		default:
+		}
+
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+
+		// m's backing array has been fully dispatched (copied out where
+		// necessary above), so it is safe to reuse for the next read.
+		buf = m
+	}
+}
+
+// ErrCodecMismatch is returned by a Session's internal decode step when an
+// inbound frame's Kind cannot be interpreted as signed-linear PCM: either
+// its Kind is not KindSlin and no codec has been configured via SetCodec, or
+// a codec has been configured but its Kind() does not match the frame's and
+// the frame is not KindSlin.  KindSlin frames are always passed through.
+var ErrCodecMismatch = errors.New("audiosocket: frame kind does not match configured codec")
+
+// decodeAudio decodes payload into signed-linear PCM using the configured
+// codec, if any, and always returns a copy: payload aliases the Session's
+// reusable read buffer, which is overwritten by the next inbound message,
+// and some codecs (e.g. codec.Slin) decode by returning their input
+// unmodified, so the result must be copied out before it escapes to the
+// Audio channel.
+func (s *Session) decodeAudio(kind Kind, payload []byte) ([]byte, error) {
+	s.codecMu.RLock()
+	c := s.codec
+	s.codecMu.RUnlock()
+
+	var decoded []byte
+	switch {
+	case c != nil && Kind(c.Kind()) == kind:
+		var err error
+		decoded, err = c.Decode(payload)
+		if err != nil {
+			return nil, err
+		}
+	case kind == KindSlin:
+		decoded = payload
+	default:
+		return nil, fmt.Errorf("%w: kind %d", ErrCodecMismatch, kind)
+	}
+
+	out := make([]byte, len(decoded))
+	copy(out, decoded)
+	return out, nil
+}
+
+func (s *Session) closeChannels() {
+	close(s.audioCh)
+	close(s.dtmfCh)
+	close(s.errCh)
+	close(s.hangupCh)
+}
@@ -0,0 +1,158 @@
+package audiosocket
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CyCoreSystems/audiosocket/codec"
+)
+
+// newTestSession wires up an in-memory net.Conn pair and a Session reading
+// from one end, so tests can write raw AudioSocket frames to the other end.
+func newTestSession(t *testing.T) (*Session, net.Conn) {
+	t.Helper()
+
+	client, server := net.Pipe()
+	t.Cleanup(func() { client.Close() })
+
+	sessionDone := make(chan struct{})
+	var s *Session
+	var err error
+	go func() {
+		s, err = NewSession(server)
+		close(sessionDone)
+	}()
+
+	if _, writeErr := client.Write(IDMessage(uuid.New())); writeErr != nil {
+		t.Fatalf("failed to write ID message: %v", writeErr)
+	}
+
+	select {
+	case <-sessionDone:
+	case <-time.After(time.Second):
+		t.Fatal("NewSession did not return")
+	}
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+
+	return s, client
+}
+
+// TestSessionAudioSurvivesBufferReuse verifies that consecutive audio
+// payloads delivered on Session.Audio() are independent of each other, even
+// though the underlying Reader reuses its read buffer across messages.
+func TestSessionAudioSurvivesBufferReuse(t *testing.T) {
+	s, client := newTestSession(t)
+	defer s.Close()
+
+	want := [][]byte{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}}
+	for _, pcm := range want {
+		if _, err := client.Write(SlinMessage(pcm)); err != nil {
+			t.Fatalf("failed to write slin message: %v", err)
+		}
+	}
+
+	for i, wantPCM := range want {
+		select {
+		case got := <-s.Audio():
+			if !bytes.Equal(got, wantPCM) {
+				t.Fatalf("audio payload %d = %v, want %v", i, got, wantPCM)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for audio payload %d", i)
+		}
+	}
+}
+
+// TestSessionRejectsOversizedFrame verifies that a Session surfaces an
+// oversized frame (one exceeding the bound the hardened Reader enforces for
+// its Kind) as an error rather than hanging or panicking.
+func TestSessionRejectsOversizedFrame(t *testing.T) {
+	s, client := newTestSession(t)
+	defer s.Close()
+
+	// KindDTMF's protocol-defined max payload size is 1 byte; claim 2. The
+	// frame is rejected from its header alone, before the (here, absent)
+	// payload would be read.
+	if _, err := client.Write([]byte{KindDTMF, 0x00, 0x02}); err != nil {
+		t.Fatalf("failed to write oversized frame header: %v", err)
+	}
+
+	select {
+	case code, ok := <-s.Errors():
+		if !ok {
+			t.Fatal("Errors channel closed without delivering an error")
+		}
+		if code != ErrUnknown {
+			t.Fatalf("error code = %v, want ErrUnknown", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for oversized-frame error")
+	}
+}
+
+// TestSessionDecodesConfiguredCodec verifies that a frame whose Kind matches
+// the codec set via SetCodec is decoded to signed-linear PCM before being
+// delivered on Audio().
+func TestSessionDecodesConfiguredCodec(t *testing.T) {
+	s, client := newTestSession(t)
+	defer s.Close()
+
+	c := codec.NewULaw()
+	s.SetCodec(c)
+
+	pcm := []byte{0x00, 0x01, 0x00, 0x02}
+	encoded, err := c.Encode(pcm)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	if _, err := client.Write(AudioMessage(KindULaw, encoded)); err != nil {
+		t.Fatalf("failed to write ulaw message: %v", err)
+	}
+
+	select {
+	case got := <-s.Audio():
+		want, err := c.Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("audio payload = %v, want %v", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for decoded audio payload")
+	}
+}
+
+// TestSessionRejectsUnconfiguredCodecMismatch verifies that a non-slin audio
+// frame arriving without a matching codec configured via SetCodec is
+// surfaced as an error, rather than being delivered on Audio() as if it were
+// already signed-linear PCM.
+func TestSessionRejectsUnconfiguredCodecMismatch(t *testing.T) {
+	s, client := newTestSession(t)
+	defer s.Close()
+
+	if _, err := client.Write(AudioMessage(KindULaw, []byte{0xff, 0xff, 0xff, 0xff})); err != nil {
+		t.Fatalf("failed to write ulaw message: %v", err)
+	}
+
+	select {
+	case payload := <-s.Audio():
+		t.Fatalf("undecoded payload %v delivered on Audio(), want an error on Errors()", payload)
+	case code, ok := <-s.Errors():
+		if !ok {
+			t.Fatal("Errors channel closed without delivering an error")
+		}
+		if code != ErrUnknown {
+			t.Fatalf("error code = %v, want ErrUnknown", code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for codec-mismatch error")
+	}
+}